@@ -0,0 +1,189 @@
+package curation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// SkipCurationAfterFailureEnv, when set to true, makes RunCurationScan log a warning instead of
+// returning an error when a Curation check fails for one or more components (those components are
+// reported as "unknown" either way).
+const SkipCurationAfterFailureEnv = "JFROG_CLI_SKIP_CURATION_AFTER_FAILURE"
+
+// curatedPolicyHeader is the response header Artifactory Curation sets on a blocked HEAD request,
+// naming the policy that blocked the component.
+const curatedPolicyHeader = "X-JFrog-Curation-Blocking-Policy"
+
+// curationConcurrencyLimit caps the number of in-flight HEAD requests, so a project with a very
+// large direct-dependency set doesn't fire thousands of concurrent requests at once.
+const curationConcurrencyLimit = 10
+
+// curationRequestTimeout bounds a single component's HEAD request, so one hung response can't block
+// the whole audit indefinitely.
+const curationRequestTimeout = 10 * time.Second
+
+var curationHttpClient = &http.Client{Timeout: curationRequestTimeout}
+
+// PackageStatus is the Curation verdict for a single direct dependency.
+type PackageStatus string
+
+const (
+	StatusAllowed PackageStatus = "allowed"
+	StatusBlocked PackageStatus = "blocked"
+	StatusUnknown PackageStatus = "unknown"
+)
+
+// ComponentCurationResult is the Curation verdict for a single direct dependency, identified by its
+// GAV/npm/pypi component ID (e.g. "gav://g:a:v").
+type ComponentCurationResult struct {
+	ComponentId    string        `json:"component_id"`
+	Status         PackageStatus `json:"status"`
+	BlockingPolicy string        `json:"blocking_policy,omitempty"`
+}
+
+// RunCurationScan consults the configured Artifactory Curation remote for each of directDependencies
+// and classifies it as allowed, blocked or unknown. It runs HEAD requests concurrently, capped at
+// curationConcurrencyLimit in flight at a time, mirroring the JAS scanners' own concurrency pattern.
+// A component whose check fails (the service is unreachable, times out, ...) is reported as
+// "unknown" rather than aborting the whole scan - one bad component shouldn't discard every other
+// component's verdict. If any component failed, RunCurationScan still returns every result, plus a
+// joined error summarizing the failures, unless JFROG_CLI_SKIP_CURATION_AFTER_FAILURE is set.
+func RunCurationScan(directDependencies []string, serverDetails *config.ServerDetails) ([]ComponentCurationResult, error) {
+	if serverDetails == nil || serverDetails.Url == "" {
+		log.Warn("To include Curation results as part of the audit output, please run the 'jf c add' command before running this command.")
+		return nil, nil
+	}
+
+	results := make([]ComponentCurationResult, len(directDependencies))
+	var resultsMutex sync.Mutex
+	var failures []error
+	errGroup := new(errgroup.Group)
+	errGroup.SetLimit(curationConcurrencyLimit)
+	for i, component := range directDependencies {
+		index, componentId := i, component
+		errGroup.Go(func() error {
+			result, err := checkComponent(componentId, serverDetails)
+			if err != nil {
+				resultsMutex.Lock()
+				failures = append(failures, fmt.Errorf("%s: %w", componentId, err))
+				resultsMutex.Unlock()
+				result = ComponentCurationResult{ComponentId: componentId, Status: StatusUnknown}
+			}
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			results[index] = result
+			// Never fail the group: a single component's failure must not stop the others
+			// (SetLimit(curationConcurrencyLimit) just bounds in-flight requests, it doesn't retry
+			// or cancel on error), and its failure is already captured above.
+			return nil
+		})
+	}
+	_ = errGroup.Wait()
+
+	if len(failures) == 0 {
+		return results, nil
+	}
+	if skipCurationAfterFailure() {
+		log.Warn(fmt.Sprintf("Curation check failed for %d component(s), marking as unknown because %s is set", len(failures), SkipCurationAfterFailureEnv))
+		return results, nil
+	}
+	return results, errors.Join(failures...)
+}
+
+// HasBlockedDependency reports whether any of results was blocked by a Curation policy.
+func HasBlockedDependency(results []ComponentCurationResult) bool {
+	for _, result := range results {
+		if result.Status == StatusBlocked {
+			return true
+		}
+	}
+	return false
+}
+
+func skipCurationAfterFailure() bool {
+	skip, _ := strconv.ParseBool(os.Getenv(SkipCurationAfterFailureEnv))
+	return skip
+}
+
+func checkComponent(componentId string, serverDetails *config.ServerDetails) (ComponentCurationResult, error) {
+	curatedUrl, err := buildCuratedRemoteUrl(componentId, serverDetails.Url)
+	if err != nil {
+		return ComponentCurationResult{}, err
+	}
+	request, err := http.NewRequest(http.MethodHead, curatedUrl, nil)
+	if errorutils.CheckError(err) != nil {
+		return ComponentCurationResult{}, err
+	}
+	setAuth(request, serverDetails)
+
+	response, err := curationHttpClient.Do(request)
+	if errorutils.CheckError(err) != nil {
+		return ComponentCurationResult{}, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	result := ComponentCurationResult{ComponentId: componentId}
+	switch response.StatusCode {
+	case http.StatusOK:
+		result.Status = StatusAllowed
+	case http.StatusForbidden:
+		result.Status = StatusBlocked
+		result.BlockingPolicy = response.Header.Get(curatedPolicyHeader)
+	default:
+		result.Status = StatusUnknown
+	}
+	return result, nil
+}
+
+func setAuth(request *http.Request, serverDetails *config.ServerDetails) {
+	if serverDetails.AccessToken != "" {
+		request.Header.Set("Authorization", "Bearer "+serverDetails.AccessToken)
+		return
+	}
+	if serverDetails.User != "" {
+		request.SetBasicAuth(serverDetails.User, serverDetails.Password)
+	}
+}
+
+// buildCuratedRemoteUrl maps a GAV/npm/pypi component ID to the path Artifactory Curation exposes
+// for it under the server's curation API, so a plain HEAD request reveals whether it's blocked.
+func buildCuratedRemoteUrl(componentId string, serverUrl string) (string, error) {
+	const (
+		gavPrefix   = "gav://"
+		npmPrefix   = "npm://"
+		pypiPrefix  = "pypi://"
+		curationApi = "api/curation/audit/"
+	)
+	baseUrl := strings.TrimSuffix(serverUrl, "/") + "/" + curationApi
+
+	switch {
+	case strings.HasPrefix(componentId, gavPrefix):
+		coordinates := strings.Split(strings.TrimPrefix(componentId, gavPrefix), ":")
+		if len(coordinates) != 3 {
+			return "", errorutils.CheckErrorf("unexpected GAV component ID: %s", componentId)
+		}
+		groupPath := strings.ReplaceAll(coordinates[0], ".", "/")
+		return fmt.Sprintf("%smaven/%s/%s/%s", baseUrl, groupPath, coordinates[1], coordinates[2]), nil
+	case strings.HasPrefix(componentId, npmPrefix):
+		nameAndVersion := strings.TrimPrefix(componentId, npmPrefix)
+		return baseUrl + "npm/" + nameAndVersion, nil
+	case strings.HasPrefix(componentId, pypiPrefix):
+		nameAndVersion := strings.TrimPrefix(componentId, pypiPrefix)
+		return baseUrl + "pypi/" + nameAndVersion, nil
+	default:
+		return "", errorutils.CheckErrorf("unsupported component ID for Curation: %s", componentId)
+	}
+}
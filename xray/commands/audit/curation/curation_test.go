@@ -0,0 +1,198 @@
+package curation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+)
+
+func TestCheckComponentAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	result, err := checkComponent("gav://org.jfrog:example:1.0.0", &config.ServerDetails{Url: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Status != StatusAllowed {
+		t.Fatalf("expected status %q, got %q", StatusAllowed, result.Status)
+	}
+}
+
+func TestCheckComponentBlocked(t *testing.T) {
+	const policyName = "block-old-versions"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(curatedPolicyHeader, policyName)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	result, err := checkComponent("npm://left-pad:1.3.0", &config.ServerDetails{Url: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Status != StatusBlocked {
+		t.Fatalf("expected status %q, got %q", StatusBlocked, result.Status)
+	}
+	if result.BlockingPolicy != policyName {
+		t.Fatalf("expected blocking policy %q, got %q", policyName, result.BlockingPolicy)
+	}
+}
+
+func TestCheckComponentUnknown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	result, err := checkComponent("pypi://requests:2.31.0", &config.ServerDetails{Url: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Status != StatusUnknown {
+		t.Fatalf("expected status %q, got %q", StatusUnknown, result.Status)
+	}
+}
+
+func TestBuildCuratedRemoteUrl(t *testing.T) {
+	tests := []struct {
+		componentId string
+		expected    string
+		expectError bool
+	}{
+		{componentId: "gav://org.jfrog:example:1.0.0", expected: "https://server/api/curation/audit/maven/org/jfrog/example/1.0.0"},
+		{componentId: "npm://left-pad:1.3.0", expected: "https://server/api/curation/audit/npm/left-pad:1.3.0"},
+		{componentId: "pypi://requests:2.31.0", expected: "https://server/api/curation/audit/pypi/requests:2.31.0"},
+		{componentId: "gav://org.jfrog:example", expectError: true},
+		{componentId: "go://github.com/some/module:1.0.0", expectError: true},
+	}
+	for _, test := range tests {
+		actual, err := buildCuratedRemoteUrl(test.componentId, "https://server")
+		if test.expectError {
+			if err == nil {
+				t.Errorf("buildCuratedRemoteUrl(%q): expected an error, got none", test.componentId)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("buildCuratedRemoteUrl(%q): unexpected error: %s", test.componentId, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("buildCuratedRemoteUrl(%q) = %q, expected %q", test.componentId, actual, test.expected)
+		}
+	}
+}
+
+func TestRunCurationScanNoServerDetails(t *testing.T) {
+	results, err := RunCurationScan([]string{"gav://org.jfrog:example:1.0.0"}, &config.ServerDetails{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if results != nil {
+		t.Fatalf("expected no results when no server is configured, got %v", results)
+	}
+}
+
+func TestRunCurationScanAllAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	components := []string{"gav://org.jfrog:a:1.0.0", "npm://left-pad:1.3.0", "pypi://requests:2.31.0"}
+	results, err := RunCurationScan(components, &config.ServerDetails{Url: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != len(components) {
+		t.Fatalf("expected %d results, got %d", len(components), len(results))
+	}
+	for i, result := range results {
+		if result.ComponentId != components[i] {
+			t.Errorf("result %d: expected component ID %q, got %q", i, components[i], result.ComponentId)
+		}
+		if result.Status != StatusAllowed {
+			t.Errorf("result %d: expected status %q, got %q", i, StatusAllowed, result.Status)
+		}
+	}
+}
+
+// TestRunCurationScanPartialFailure exercises an unsupported component ID, which fails at URL-building
+// time rather than over the network. It should still be reported as "unknown" alongside the other
+// components' real results, and the joined error should mention it, unless the skip env var is set.
+func TestRunCurationScanPartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	components := []string{"gav://org.jfrog:a:1.0.0", "go://github.com/some/module:1.0.0"}
+	results, err := RunCurationScan(components, &config.ServerDetails{Url: server.URL})
+	if err == nil {
+		t.Fatal("expected an error summarizing the failed component")
+	}
+	if len(results) != len(components) {
+		t.Fatalf("expected %d results even though one component failed, got %d", len(components), len(results))
+	}
+	if results[0].Status != StatusAllowed {
+		t.Errorf("expected the first component to still be resolved, got status %q", results[0].Status)
+	}
+	if results[1].Status != StatusUnknown {
+		t.Errorf("expected the failed component to be marked %q, got %q", StatusUnknown, results[1].Status)
+	}
+}
+
+func TestRunCurationScanPartialFailureSkippedByEnv(t *testing.T) {
+	if err := os.Setenv(SkipCurationAfterFailureEnv, "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Unsetenv(SkipCurationAfterFailureEnv)
+	}()
+
+	components := []string{"go://github.com/some/module:1.0.0"}
+	results, err := RunCurationScan(components, &config.ServerDetails{Url: "https://server"})
+	if err != nil {
+		t.Fatalf("expected no error once %s is set, got: %s", SkipCurationAfterFailureEnv, err)
+	}
+	if len(results) != 1 || results[0].Status != StatusUnknown {
+		t.Fatalf("expected a single unknown result, got %v", results)
+	}
+}
+
+// TestRunCurationScanConcurrencyLimit checks that no more than curationConcurrencyLimit HEAD requests
+// are ever in flight at once, even with a component count well above the limit.
+func TestRunCurationScanConcurrencyLimit(t *testing.T) {
+	var inFlight, maxInFlight int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			observedMax := atomic.LoadInt64(&maxInFlight)
+			if current <= observedMax || atomic.CompareAndSwapInt64(&maxInFlight, observedMax, current) {
+				break
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var components []string
+	for i := 0; i < curationConcurrencyLimit*3; i++ {
+		components = append(components, fmt.Sprintf("npm://dep-%d:1.0.0", i))
+	}
+	if _, err := RunCurationScan(components, &config.ServerDetails{Url: server.URL}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if maxInFlight > curationConcurrencyLimit {
+		t.Fatalf("expected at most %d concurrent requests, observed %d", curationConcurrencyLimit, maxInFlight)
+	}
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/sca/java"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/xray"
+	scanservices "github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// AuditJavaModule builds tech's dependency tree, sends it to Xray as one scan-graph request per
+// module - carrying whatever violation context (Watches/ProjectKey/TargetRepoPath) params was
+// configured with, so Xray returns policy-gated violations instead of raw vulnerabilities when one
+// applies - and runs the JAS scanners over the same tree, merging everything into a single
+// ExtendedScanResults.
+func AuditJavaModule(ctx context.Context, params utils.AuditParams, tech coreutils.Technology, workingDirs []string, progress io.ProgressMgr, failOnCurationBlocked bool) (*utils.ExtendedScanResults, error) {
+	dependencyTree, uniqueDeps, err := java.BuildDependencyTree(params, tech)
+	if err != nil {
+		return nil, err
+	}
+	serverDetails, err := params.ServerDetails()
+	if err != nil {
+		return nil, err
+	}
+	treeParams := &java.DependencyTreeParams{Watches: params.Watches(), ProjectKey: params.ProjectKey(), TargetRepoPath: params.TargetRepoPath()}
+
+	scanResults := utils.NewExtendedScanResults()
+	for _, scanGraphParams := range java.BuildScanGraphParams(dependencyTree, treeParams) {
+		scanResponse, scanErr := runScanGraph(scanGraphParams, serverDetails)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		scanResults.XrayResults = append(scanResults.XrayResults, *scanResponse)
+	}
+
+	if err = runJasScannersAndSetResults(ctx, scanResults, uniqueDeps, serverDetails, workingDirs, progress,
+		treeParams.Watches, treeParams.ProjectKey, treeParams.TargetRepoPath, failOnCurationBlocked); err != nil {
+		return nil, err
+	}
+	return scanResults, nil
+}
+
+// runScanGraph sends a single module's dependency tree to Xray's scan-graph API and blocks until the
+// scan completes, returning the resulting vulnerabilities/violations.
+func runScanGraph(scanGraphParams *scanservices.XrayGraphScanParams, serverDetails *config.ServerDetails) (*scanservices.ScanResponse, error) {
+	xrayAuth, err := serverDetails.CreateXrayAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	xrayManager, err := xray.CreateXrayServiceManager(xrayAuth)
+	if err != nil {
+		return nil, err
+	}
+	scanId, err := xrayManager.ScanGraph(scanGraphParams)
+	if err != nil {
+		return nil, err
+	}
+	return xrayManager.GetScanGraphResults(scanId, scanGraphParams.IncludeVulnerabilities, scanGraphParams.IncludeLicenses)
+}
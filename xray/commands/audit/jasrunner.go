@@ -1,9 +1,14 @@
 package audit
 
 import (
+	"context"
 	"errors"
+	"strings"
+	"sync"
+
 	"github.com/jfrog/gofrog/version"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/curation"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/jas"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/jas/applicability"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/jas/iac"
@@ -12,10 +17,59 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/xray/utils"
 	"github.com/jfrog/jfrog-client-go/utils/io"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/sync/errgroup"
 )
 
-func runJasScannersAndSetResults(scanResults *utils.ExtendedScanResults, directDependencies []string,
-	serverDetails *config.ServerDetails, workingDirs []string, progress io.ProgressMgr) (err error) {
+// runningHeadline aggregates the names of the JAS scanners that are currently in flight, so the
+// progress bar shows a single headline (e.g. "Running: applicability, secrets, SAST") instead of
+// one scanner's message overwriting another's.
+type runningHeadline struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (r *runningHeadline) add(progress io.ProgressMgr, name string) {
+	if progress == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+	progress.SetHeadlineMsg("Running: " + strings.Join(r.names, ", "))
+}
+
+func (r *runningHeadline) remove(progress io.ProgressMgr, name string) {
+	if progress == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, n := range r.names {
+		if n == name {
+			r.names = append(r.names[:i], r.names[i+1:]...)
+			break
+		}
+	}
+	if len(r.names) > 0 {
+		progress.SetHeadlineMsg("Running: " + strings.Join(r.names, ", "))
+	}
+}
+
+// runJasScannersAndSetResults runs the applicability, secrets, IaC, SAST and Curation scanners
+// concurrently. Each scanner writes into its own field of scanResults under resultsMutex. The
+// derived ctx returned by errgroup.WithContext is cancelled as soon as any scanner fails; none of
+// the scan functions take a context themselves, so cancellation is checked at the top of each
+// goroutine and only prevents scanners that haven't started yet from doing so - it can't interrupt
+// one already in flight. The returned error joins every scanner's real error (not just the first, as
+// a bare errgroup.Wait() would give us) - a scanner that only ever saw ctx already cancelled is
+// skipped rather than run, so its context.Canceled is dropped to keep the joined error readable; if
+// ctx was already done before any scanner got to record a real failure, that cancellation is still
+// surfaced on its own. scanResults retains the partial results of whichever scanners did complete, so
+// a single slow or broken analyzer doesn't block or discard the others.
+func runJasScannersAndSetResults(ctx context.Context, scanResults *utils.ExtendedScanResults, directDependencies []string,
+	serverDetails *config.ServerDetails, workingDirs []string, progress io.ProgressMgr, watches []string, projectKey string, repoPath string,
+	failOnCurationBlocked bool) (err error) {
+	scanResults.HasViolationContext = len(watches) > 0 || projectKey != "" || repoPath != ""
 	if serverDetails == nil || len(serverDetails.Url) == 0 {
 		log.Warn("To include 'Advanced Security' scan as part of the audit output, please run the 'jf c add' command before running this command.")
 		return
@@ -28,33 +82,126 @@ func runJasScannersAndSetResults(scanResults *utils.ExtendedScanResults, directD
 		cleanup := scanner.ScannerDirCleanupFunc
 		err = errors.Join(err, cleanup())
 	}()
-	if progress != nil {
-		progress.SetHeadlineMsg("Running applicability scanning")
-	}
-	scanResults.ApplicabilityScanResults, err = applicability.RunApplicabilityScan(scanResults.XrayResults, directDependencies, scanResults.ScannedTechnologies, scanner)
-	if err != nil {
-		return
-	}
-	if progress != nil {
-		progress.SetHeadlineMsg("Running secrets scanning")
-	}
-	scanResults.SecretsScanResults, err = secrets.RunSecretsScan(scanner)
-	if err != nil {
-		return
+
+	callerCtx := ctx
+	errGroup, ctx := errgroup.WithContext(ctx)
+	var resultsMutex sync.Mutex
+	var scanErrors []error
+	headline := &runningHeadline{}
+
+	// recordAndCancel stores scanErr (if any) so it survives past errGroup.Wait() - which only
+	// returns the first error - and returns it so errGroup still cancels ctx for the other scanners.
+	// A scanner that merely observed ctx already cancelled (because a sibling scanner failed first)
+	// never ran at all, so its context.Canceled is noise, not a failure in its own right - it's
+	// dropped from scanErrors rather than drowning out the scanner that actually failed.
+	recordAndCancel := func(scanErr error) error {
+		if scanErr == nil {
+			return nil
+		}
+		if !errors.Is(scanErr, context.Canceled) {
+			resultsMutex.Lock()
+			scanErrors = append(scanErrors, scanErr)
+			resultsMutex.Unlock()
+		}
+		return scanErr
 	}
-	if progress != nil {
-		progress.SetHeadlineMsg("Running IaC scanning")
+
+	errGroup.Go(func() error {
+		headline.add(progress, "applicability")
+		defer headline.remove(progress, "applicability")
+		if ctx.Err() != nil {
+			return recordAndCancel(ctx.Err())
+		}
+		results, scanErr := applicability.RunApplicabilityScan(scanResults.XrayResults, directDependencies, scanResults.ScannedTechnologies, scanner)
+		if scanErr != nil {
+			return recordAndCancel(scanErr)
+		}
+		resultsMutex.Lock()
+		defer resultsMutex.Unlock()
+		scanResults.ApplicabilityScanResults = results
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		headline.add(progress, "secrets")
+		defer headline.remove(progress, "secrets")
+		if ctx.Err() != nil {
+			return recordAndCancel(ctx.Err())
+		}
+		results, scanErr := secrets.RunSecretsScan(scanner)
+		if scanErr != nil {
+			return recordAndCancel(scanErr)
+		}
+		resultsMutex.Lock()
+		defer resultsMutex.Unlock()
+		scanResults.SecretsScanResults = results
+		return nil
+	})
+
+	errGroup.Go(func() error {
+		headline.add(progress, "IaC")
+		defer headline.remove(progress, "IaC")
+		if ctx.Err() != nil {
+			return recordAndCancel(ctx.Err())
+		}
+		results, scanErr := iac.RunIacScan(scanner)
+		if scanErr != nil {
+			return recordAndCancel(scanErr)
+		}
+		resultsMutex.Lock()
+		defer resultsMutex.Unlock()
+		scanResults.IacScanResults = results
+		return nil
+	})
+
+	if version.NewVersion(utils.AnalyzerManagerVersion).AtLeast(utils.MinAnalyzerManagerVersionForSast) {
+		errGroup.Go(func() error {
+			headline.add(progress, "SAST")
+			defer headline.remove(progress, "SAST")
+			if ctx.Err() != nil {
+				return recordAndCancel(ctx.Err())
+			}
+			results, scanErr := sast.RunSastScan(scanner)
+			if scanErr != nil {
+				return recordAndCancel(scanErr)
+			}
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			scanResults.SastResults = results
+			return nil
+		})
 	}
-	scanResults.IacScanResults, err = iac.RunIacScan(scanner)
-	if err != nil {
+
+	errGroup.Go(func() error {
+		headline.add(progress, "Curation")
+		defer headline.remove(progress, "Curation")
+		if ctx.Err() != nil {
+			return recordAndCancel(ctx.Err())
+		}
+		results, scanErr := curation.RunCurationScan(directDependencies, serverDetails)
+		if scanErr != nil {
+			return recordAndCancel(scanErr)
+		}
+		resultsMutex.Lock()
+		defer resultsMutex.Unlock()
+		scanResults.CurationScanResults = results
+		return nil
+	})
+
+	// Discard errGroup.Wait()'s return value: it's only ever the first error recorded, and we've
+	// already captured every scanner's error in scanErrors above.
+	_ = errGroup.Wait()
+	if err = errors.Join(scanErrors...); err != nil {
 		return
 	}
-	if !version.NewVersion(utils.AnalyzerManagerVersion).AtLeast(utils.MinAnalyzerManagerVersionForSast) {
+	// scanErrors filters out context.Canceled, so if the caller's own ctx was already done before any
+	// scanner got a chance to record a real failure, that cancellation would otherwise go unreported.
+	if callerCtx.Err() != nil {
+		err = callerCtx.Err()
 		return
 	}
-	if progress != nil {
-		progress.SetHeadlineMsg("Running SAST scanning")
+	if failOnCurationBlocked && curation.HasBlockedDependency(scanResults.CurationScanResults) {
+		err = errors.New("audit failed: one or more direct dependencies are blocked by an Artifactory Curation policy")
 	}
-	scanResults.SastResults, err = sast.RunSastScan(scanner)
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,120 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type cycloneDXDocument struct {
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	Version      int                   `json:"version"`
+	Components   []cycloneDXComponent  `json:"components,omitempty"`
+	Dependencies []cycloneDXDependency `json:"dependencies,omitempty"`
+}
+
+type cycloneDXComponent struct {
+	Type    string `json:"type"`
+	BomRef  string `json:"bom-ref"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Purl    string `json:"purl"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+func writeCycloneDX(components []*xrayUtils.GraphNode, edges []graphEdge, w io.Writer) error {
+	doc := cycloneDXDocument{
+		BomFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+	}
+	for _, component := range components {
+		purl := componentIdToPurl(component.Id)
+		doc.Components = append(doc.Components, cycloneDXComponent{
+			Type:    "library",
+			BomRef:  purl,
+			Name:    component.Id,
+			Version: gavVersion(component.Id),
+			Purl:    purl,
+		})
+	}
+
+	dependsOn := map[string][]string{}
+	for _, edge := range edges {
+		parentPurl, childPurl := componentIdToPurl(edge.parent), componentIdToPurl(edge.child)
+		dependsOn[parentPurl] = append(dependsOn[parentPurl], childPurl)
+	}
+	for _, component := range components {
+		purl := componentIdToPurl(component.Id)
+		doc.Dependencies = append(doc.Dependencies, cycloneDXDependency{Ref: purl, DependsOn: dependsOn[purl]})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errorutils.CheckError(encoder.Encode(doc))
+}
+
+func readCycloneDX(content []byte) (modules []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	var doc cycloneDXDocument
+	if err = errorutils.CheckError(json.Unmarshal(content, &doc)); err != nil {
+		return nil, nil, err
+	}
+
+	nodesByRef := make(map[string]*xrayUtils.GraphNode, len(doc.Components))
+	for _, component := range doc.Components {
+		nodesByRef[component.BomRef] = &xrayUtils.GraphNode{Id: purlToComponentId(component.Purl), Nodes: []*xrayUtils.GraphNode{}}
+	}
+
+	isDependency := map[string]bool{}
+	for _, dependency := range doc.Dependencies {
+		parent, ok := nodesByRef[dependency.Ref]
+		if !ok {
+			continue
+		}
+		for _, childRef := range dependency.DependsOn {
+			child, childOk := nodesByRef[childRef]
+			if !childOk {
+				continue
+			}
+			child.Parent = parent
+			parent.Nodes = append(parent.Nodes, child)
+			isDependency[childRef] = true
+		}
+	}
+
+	// Walk doc.Components in document order, not nodesByRef (a map), so importing the same document
+	// twice returns the same tree in the same order.
+	for _, component := range doc.Components {
+		node := nodesByRef[component.BomRef]
+		uniqueDeps = append(uniqueDeps, node.Id)
+		if !isDependency[component.BomRef] {
+			modules = append(modules, node)
+		}
+	}
+	return modules, uniqueDeps, nil
+}
+
+// gavVersion extracts the version segment ("v" in "gav://g:a:v") from a component ID, for the
+// CycloneDX "version" field. For any component ID it returns the text after the last ':' (so
+// "1.3.0" for "npm://left-pad:1.3.0"), or "" if the ID has no ':' at all.
+func gavVersion(componentId string) string {
+	idx := -1
+	for i := len(componentId) - 1; i >= 0; i-- {
+		if componentId[i] == ':' {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return ""
+	}
+	return componentId[idx+1:]
+}
@@ -0,0 +1,160 @@
+// Package sbom exports and imports the dependency graph built by the tech-specific audit builders
+// (xray/commands/audit/sca/...) as a CycloneDX or SPDX SBOM document, so pre-built artifacts and
+// third-party SBOMs can be fed into the same Xray scan-graph flow as a freshly resolved dependency
+// tree.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Format is an SBOM serialization format supported by ExportSBOM/ImportSBOM.
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSpdx      Format = "spdx"
+
+	cycloneDXSpecVersion = "1.5"
+	spdxVersion          = "SPDX-2.3"
+
+	gavPackageTypeIdentifier = "gav://"
+)
+
+// ExportSBOM serializes modules - the dependency graph produced by a tech builder's
+// createGavDependencyTree/getGraphFromDepTree - into the given SBOM format and writes it to w.
+// Parent/child edges are preserved as CycloneDX "dependencies"/SPDX "relationships".
+func ExportSBOM(modules []*xrayUtils.GraphNode, format Format, w io.Writer) error {
+	components, edges := flattenGraph(modules)
+	switch format {
+	case FormatCycloneDX:
+		return writeCycloneDX(components, edges, w)
+	case FormatSpdx:
+		return writeSpdx(components, edges, w)
+	default:
+		return errorutils.CheckErrorf("unsupported SBOM format: %s", format)
+	}
+}
+
+// ImportSBOM reads a CycloneDX or SPDX JSON document from r and reconstructs it as a dependency
+// graph, so BuildDependencyTree can accept --sbom=path.json and skip build-tool invocation entirely.
+func ImportSBOM(r io.Reader) (modules []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	content, err := io.ReadAll(r)
+	if errorutils.CheckError(err) != nil {
+		return nil, nil, err
+	}
+
+	var probe struct {
+		BomFormat   string `json:"bomFormat"`
+		SpdxVersion string `json:"spdxVersion"`
+	}
+	if err = errorutils.CheckError(json.Unmarshal(content, &probe)); err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case strings.EqualFold(probe.BomFormat, "CycloneDX"):
+		return readCycloneDX(content)
+	case probe.SpdxVersion != "":
+		return readSpdx(content)
+	default:
+		return nil, nil, errorutils.CheckErrorf("unrecognized SBOM document: neither a CycloneDX 'bomFormat' nor an SPDX 'spdxVersion' field was found")
+	}
+}
+
+// graphEdge is a parent/child relationship between two component IDs, keyed the same way CycloneDX
+// "dependencies" and SPDX "relationships" key their own edges.
+type graphEdge struct {
+	parent string
+	child  string
+}
+
+// flattenGraph walks modules with an explicit queue (not recursion, for the same reason
+// populateDependencyTree in the java builder does) and returns every unique component together with
+// the parent/child edges between them.
+func flattenGraph(modules []*xrayUtils.GraphNode) (components []*xrayUtils.GraphNode, edges []graphEdge) {
+	visited := map[string]bool{}
+	queue := append([]*xrayUtils.GraphNode{}, modules...)
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if visited[node.Id] {
+			continue
+		}
+		visited[node.Id] = true
+		components = append(components, node)
+		for _, child := range node.Nodes {
+			edges = append(edges, graphEdge{parent: node.Id, child: child.Id})
+			queue = append(queue, child)
+		}
+	}
+	return
+}
+
+// componentIdToPurl maps a component ID of the shape "gav://g:a:v" to its Package URL
+// "pkg:maven/g/a@v". Component IDs of other package types (npm://, pypi://, ...) are passed through
+// with their scheme replaced by "pkg:", since jfrog-client-go's package-type prefixes are already
+// purl-compatible namespaces. A literal "@" in the name itself (npm scoped packages, e.g.
+// "@angular/core") is percent-encoded first, per the purl spec, so it isn't confused with the "@"
+// that separates name from version.
+func componentIdToPurl(componentId string) string {
+	if strings.HasPrefix(componentId, gavPackageTypeIdentifier) {
+		gav := strings.TrimPrefix(componentId, gavPackageTypeIdentifier)
+		parts := strings.SplitN(gav, ":", 3)
+		if len(parts) == 3 {
+			return fmt.Sprintf("pkg:maven/%s/%s@%s", parts[0], parts[1], parts[2])
+		}
+	}
+	if schemeIdx := strings.Index(componentId, "://"); schemeIdx != -1 {
+		scheme := componentId[:schemeIdx]
+		rest := componentId[schemeIdx+3:]
+		name, version, hasVersion := strings.Cut(rest, ":")
+		escapedName := strings.ReplaceAll(name, "@", "%40")
+		if hasVersion {
+			return fmt.Sprintf("pkg:%s/%s@%s", scheme, escapedName, version)
+		}
+		return fmt.Sprintf("pkg:%s/%s", scheme, escapedName)
+	}
+	return componentId
+}
+
+// purlToComponentId is the inverse of componentIdToPurl, used by ImportSBOM to rebuild the component
+// IDs the rest of the audit pipeline expects (e.g. "gav://g:a:v", "npm://name:version"). Maven purls
+// carry an extra path segment (the groupId) that the generic "pkg:<scheme>/<name>@<version>" shape
+// other package types use doesn't have, so it's inverted separately from the generic case.
+func purlToComponentId(purl string) string {
+	const mavenPrefix = "pkg:maven/"
+	if strings.HasPrefix(purl, mavenPrefix) {
+		coordinates := strings.TrimPrefix(purl, mavenPrefix)
+		if groupAndArtifact, version, found := strings.Cut(coordinates, "@"); found {
+			group, artifact, hasArtifact := strings.Cut(groupAndArtifact, "/")
+			if hasArtifact {
+				return fmt.Sprintf("%s%s:%s:%s", gavPackageTypeIdentifier, group, artifact, version)
+			}
+		}
+		return purl
+	}
+
+	const genericPrefix = "pkg:"
+	if strings.HasPrefix(purl, genericPrefix) {
+		scheme, nameAndVersion, found := strings.Cut(strings.TrimPrefix(purl, genericPrefix), "/")
+		if found {
+			// The name's own "@" (e.g. an npm scope) was percent-encoded by componentIdToPurl, so the
+			// first literal "@" left in nameAndVersion is always the version separator.
+			name, version, hasVersion := strings.Cut(nameAndVersion, "@")
+			unescapedName := strings.ReplaceAll(name, "%40", "@")
+			if hasVersion {
+				return fmt.Sprintf("%s://%s:%s", scheme, unescapedName, version)
+			}
+			return scheme + "://" + unescapedName
+		}
+	}
+	return purl
+}
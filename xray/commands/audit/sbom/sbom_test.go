@@ -0,0 +1,147 @@
+package sbom
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+func sampleGraph() []*xrayUtils.GraphNode {
+	transitive := &xrayUtils.GraphNode{Id: "gav://org.jfrog:transitive:2.0.0"}
+	direct := &xrayUtils.GraphNode{Id: "gav://org.jfrog:direct:1.0.0", Nodes: []*xrayUtils.GraphNode{transitive}}
+	npmDep := &xrayUtils.GraphNode{Id: "npm://left-pad:1.3.0"}
+	scopedNpmDep := &xrayUtils.GraphNode{Id: "npm://@angular/core:14.0.0"}
+	return []*xrayUtils.GraphNode{direct, npmDep, scopedNpmDep}
+}
+
+func componentIds(modules []*xrayUtils.GraphNode) []string {
+	_, edges := flattenGraph(modules)
+	ids := map[string]bool{}
+	for _, module := range modules {
+		ids[module.Id] = true
+	}
+	for _, edge := range edges {
+		ids[edge.parent] = true
+		ids[edge.child] = true
+	}
+	var sorted []string
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestCycloneDXRoundTrip(t *testing.T) {
+	graph := sampleGraph()
+	var buf bytes.Buffer
+	if err := ExportSBOM(graph, FormatCycloneDX, &buf); err != nil {
+		t.Fatalf("ExportSBOM failed: %s", err)
+	}
+
+	imported, _, err := ImportSBOM(&buf)
+	if err != nil {
+		t.Fatalf("ImportSBOM failed: %s", err)
+	}
+
+	expected := componentIds(graph)
+	actual := componentIds(imported)
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d components, got %d (%v vs %v)", len(expected), len(actual), expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("component mismatch at index %d: expected %q, got %q", i, expected[i], actual[i])
+		}
+	}
+}
+
+func TestSpdxRoundTrip(t *testing.T) {
+	graph := sampleGraph()
+	var buf bytes.Buffer
+	if err := ExportSBOM(graph, FormatSpdx, &buf); err != nil {
+		t.Fatalf("ExportSBOM failed: %s", err)
+	}
+
+	imported, _, err := ImportSBOM(&buf)
+	if err != nil {
+		t.Fatalf("ImportSBOM failed: %s", err)
+	}
+
+	expected := componentIds(graph)
+	actual := componentIds(imported)
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d components, got %d (%v vs %v)", len(expected), len(actual), expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("component mismatch at index %d: expected %q, got %q", i, expected[i], actual[i])
+		}
+	}
+}
+
+// TestImportSBOMSetsParentAndIsDeterministic checks two things readCycloneDX/readSpdx must get right
+// for the imported tree to be usable by the rest of the audit pipeline: every non-root node has its
+// Parent set (NodeHasLoop and friends walk the parent chain), and importing the same document twice
+// returns components in the same order (readCycloneDX/readSpdx used to range over a map).
+func TestImportSBOMSetsParentAndIsDeterministic(t *testing.T) {
+	for _, format := range []Format{FormatCycloneDX, FormatSpdx} {
+		var buf bytes.Buffer
+		if err := ExportSBOM(sampleGraph(), format, &buf); err != nil {
+			t.Fatalf("[%s] ExportSBOM failed: %s", format, err)
+		}
+		docBytes := buf.Bytes()
+
+		first, _, err := ImportSBOM(bytes.NewReader(docBytes))
+		if err != nil {
+			t.Fatalf("[%s] ImportSBOM failed: %s", format, err)
+		}
+		second, _, err := ImportSBOM(bytes.NewReader(docBytes))
+		if err != nil {
+			t.Fatalf("[%s] ImportSBOM failed: %s", format, err)
+		}
+		if len(first) != len(second) {
+			t.Fatalf("[%s] expected the same number of modules across imports, got %d vs %d", format, len(first), len(second))
+		}
+		for i := range first {
+			if first[i].Id != second[i].Id {
+				t.Fatalf("[%s] import order isn't deterministic: module %d was %q, then %q", format, i, first[i].Id, second[i].Id)
+			}
+		}
+
+		var direct *xrayUtils.GraphNode
+		for _, module := range first {
+			if module.Id == "gav://org.jfrog:direct:1.0.0" {
+				direct = module
+			}
+		}
+		if direct == nil {
+			t.Fatalf("[%s] expected to find the imported 'direct' module", format)
+		}
+		if len(direct.Nodes) != 1 {
+			t.Fatalf("[%s] expected 'direct' to have 1 child, got %d", format, len(direct.Nodes))
+		}
+		transitive := direct.Nodes[0]
+		if transitive.Parent != direct {
+			t.Fatalf("[%s] expected the imported transitive node's Parent to be its direct parent", format)
+		}
+	}
+}
+
+func TestComponentIdPurlRoundTrip(t *testing.T) {
+	tests := []string{
+		"gav://org.jfrog:example:1.0.0",
+		"npm://left-pad:1.3.0",
+		"npm://@angular/core:14.0.0",
+		"pypi://requests:2.31.0",
+	}
+	for _, componentId := range tests {
+		purl := componentIdToPurl(componentId)
+		roundTripped := purlToComponentId(purl)
+		if roundTripped != componentId {
+			t.Errorf("round trip failed for %q: got purl %q, then component ID %q", componentId, purl, roundTripped)
+		}
+	}
+}
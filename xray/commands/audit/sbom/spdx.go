@@ -0,0 +1,118 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type spdxDocument struct {
+	SpdxVersion   string             `json:"spdxVersion"`
+	DataLicense   string             `json:"dataLicense"`
+	SpdxId        string             `json:"SPDXID"`
+	Name          string             `json:"name"`
+	Packages      []spdxPackage      `json:"packages,omitempty"`
+	Relationships []spdxRelationship `json:"relationships,omitempty"`
+}
+
+type spdxPackage struct {
+	SpdxId       string            `json:"SPDXID"`
+	Name         string            `json:"name"`
+	VersionInfo  string            `json:"versionInfo"`
+	ExternalRefs []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+func writeSpdx(components []*xrayUtils.GraphNode, edges []graphEdge, w io.Writer) error {
+	doc := spdxDocument{
+		SpdxVersion: spdxVersion,
+		DataLicense: "CC0-1.0",
+		SpdxId:      "SPDXRef-DOCUMENT",
+		Name:        "jfrog-cli-core-audit-sbom",
+	}
+	spdxIdFor := func(componentId string) string {
+		return "SPDXRef-Package-" + componentId
+	}
+	for _, component := range components {
+		doc.Packages = append(doc.Packages, spdxPackage{
+			SpdxId:      spdxIdFor(component.Id),
+			Name:        component.Id,
+			VersionInfo: gavVersion(component.Id),
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  componentIdToPurl(component.Id),
+			}},
+		})
+	}
+	for _, edge := range edges {
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SpdxElementId:      spdxIdFor(edge.parent),
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSpdxElement: spdxIdFor(edge.child),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errorutils.CheckError(encoder.Encode(doc))
+}
+
+func readSpdx(content []byte) (modules []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	var doc spdxDocument
+	if err = errorutils.CheckError(json.Unmarshal(content, &doc)); err != nil {
+		return nil, nil, err
+	}
+
+	nodesBySpdxId := make(map[string]*xrayUtils.GraphNode, len(doc.Packages))
+	for _, pkg := range doc.Packages {
+		componentId := pkg.Name
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				componentId = purlToComponentId(ref.ReferenceLocator)
+				break
+			}
+		}
+		nodesBySpdxId[pkg.SpdxId] = &xrayUtils.GraphNode{Id: componentId, Nodes: []*xrayUtils.GraphNode{}}
+	}
+
+	isDependency := map[string]bool{}
+	for _, relationship := range doc.Relationships {
+		if relationship.RelationshipType != "DEPENDS_ON" {
+			continue
+		}
+		parent, parentOk := nodesBySpdxId[relationship.SpdxElementId]
+		child, childOk := nodesBySpdxId[relationship.RelatedSpdxElement]
+		if !parentOk || !childOk {
+			continue
+		}
+		child.Parent = parent
+		parent.Nodes = append(parent.Nodes, child)
+		isDependency[relationship.RelatedSpdxElement] = true
+	}
+
+	// Walk doc.Packages in document order, not nodesBySpdxId (a map), so importing the same document
+	// twice returns the same tree in the same order.
+	for _, pkg := range doc.Packages {
+		node := nodesBySpdxId[pkg.SpdxId]
+		uniqueDeps = append(uniqueDeps, node.Id)
+		if !isDependency[pkg.SpdxId] {
+			modules = append(modules, node)
+		}
+	}
+	return modules, uniqueDeps, nil
+}
@@ -0,0 +1,255 @@
+package java
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+//go:embed resources/maven-dep-tree.jar resources/gradle-dep-tree.jar
+var depTreeResources embed.FS
+
+const (
+	mavenDepTreeJarName  = "maven-dep-tree.jar"
+	gradleDepTreeJarName = "gradle-dep-tree.jar"
+
+	artifactExtJar = ".jar"
+	artifactExtWar = ".war"
+	artifactExtEar = ".ear"
+
+	pomPropertiesSuffix = "pom.properties"
+	manifestPath        = "META-INF/MANIFEST.MF"
+)
+
+// buildContainerlessDependencyTree builds the dependency tree without shelling out to the project's
+// own mvn/gradle: the bundled maven-dep-tree/gradle-dep-tree JAR is extracted into a per-invocation
+// temp dir and driven with params.JavaHome, exactly like the wrapper-driven flow, except that no
+// local build tool installation is required. If no Maven/Gradle project manifest can be found at
+// all, it falls back to scanning a directory of pre-built .jar/.war/.ear artifacts directly.
+func buildContainerlessDependencyTree(params *DependencyTreeParams) (modules []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	if !hasBuildToolManifest(params.Tool) {
+		// No dep-tree JAR involved at all in this path, so it doesn't depend on the embedded JARs
+		// being present - don't extract/validate one it won't use.
+		log.Info(fmt.Sprintf("No %s project file was found; scanning pre-built artifacts instead", params.Tool))
+		return buildDependencyTreeFromArtifacts(".")
+	}
+
+	depTreeJarPath, cleanup, err := extractEmbeddedDepTreeJar(params.Tool)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(cleanup()))
+	}()
+	log.Debug(fmt.Sprintf("Running containerless %s dependency resolution with %s", params.Tool, depTreeJarPath))
+
+	depTreeOutput, err := runDepTreeJar(depTreeJarPath, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return getGraphFromDepTree(depTreeOutput)
+}
+
+// extractEmbeddedDepTreeJar writes the embedded dep-tree JAR matching tech into a fresh temp dir and
+// returns its path along with a cleanup function that removes the temp dir.
+func extractEmbeddedDepTreeJar(tech coreutils.Technology) (jarPath string, cleanup func() error, err error) {
+	jarName := mavenDepTreeJarName
+	if tech != coreutils.Maven {
+		jarName = gradleDepTreeJarName
+	}
+	jarContent, err := depTreeResources.ReadFile("resources/" + jarName)
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	if err = validateJar(jarContent); err != nil {
+		return "", nil, errorutils.CheckErrorf("embedded %s is missing or invalid in this build of jfrog-cli-core; containerless dependency resolution is unavailable: %s", jarName, err.Error())
+	}
+
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup = func() error {
+		return fileutils.RemoveTempDir(tempDirPath)
+	}
+	jarPath = filepath.Join(tempDirPath, jarName)
+	if err = os.WriteFile(jarPath, jarContent, 0666); err != nil {
+		return "", cleanup, errorutils.CheckError(err)
+	}
+	return jarPath, cleanup, nil
+}
+
+// validateJar rejects an empty or non-JAR payload up front, so a mis-packaged build fails with a
+// clear, actionable error instead of "java -jar" failing cryptically on a 0-byte/corrupt file.
+func validateJar(jarContent []byte) error {
+	_, err := zip.NewReader(bytes.NewReader(jarContent), int64(len(jarContent)))
+	return err
+}
+
+// runDepTreeJar invokes the extracted dep-tree JAR with params.JavaHome (or the JDK found on the
+// PATH, if JavaHome isn't set) and returns the plugin's output - a newline-separated list of paths
+// to the JSON files it produced, in the same shape buildMvnDependencyTree/buildGradleDependencyTree
+// expect from the wrapper-driven flow.
+func runDepTreeJar(jarPath string, params *DependencyTreeParams) ([]byte, error) {
+	javaExecPath := "java"
+	if params.JavaHome != "" {
+		javaExecPath = filepath.Join(params.JavaHome, "bin", "java")
+	}
+	args := []string{"-jar", jarPath}
+	if params.ExcludeTestDeps {
+		args = append(args, "--exclude-test-deps")
+	}
+	output, err := exec.Command(javaExecPath, args...).Output()
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed running containerless dependency resolution: %s", err.Error())
+	}
+	return output, nil
+}
+
+// hasBuildToolManifest reports whether the current directory looks like a Maven/Gradle project, so
+// containerless mode knows whether to drive the dep-tree JAR or fall back to scanning artifacts.
+func hasBuildToolManifest(tech coreutils.Technology) bool {
+	var manifestNames []string
+	if tech == coreutils.Maven {
+		manifestNames = []string{"pom.xml"}
+	} else {
+		manifestNames = []string{"build.gradle", "build.gradle.kts"}
+	}
+	for _, name := range manifestNames {
+		if exists, err := fileutils.IsFileExists(name, false); err == nil && exists {
+			return true
+		}
+	}
+	return false
+}
+
+// buildDependencyTreeFromArtifacts scans every .jar/.war/.ear file directly under artifactsDir,
+// resolves its GAV coordinates from pom.properties (preferred) or MANIFEST.MF, and builds a flat
+// moduleDepTree per artifact - each artifact becomes the root of its own module, with no children,
+// since pre-built artifacts carry no transitive dependency metadata of their own.
+func buildDependencyTreeFromArtifacts(artifactsDir string) (modules []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
+	entries, err := os.ReadDir(artifactsDir)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	var depTrees []*moduleDepTree
+	for _, entry := range entries {
+		if entry.IsDir() || !isSupportedArtifact(entry.Name()) {
+			continue
+		}
+		gav, gavErr := readArtifactGav(filepath.Join(artifactsDir, entry.Name()))
+		if gavErr != nil {
+			log.Warn(fmt.Sprintf("Skipping %s: %s", entry.Name(), gavErr.Error()))
+			continue
+		}
+		moduleId := gav
+		depTrees = append(depTrees, &moduleDepTree{
+			Root: moduleId,
+			Nodes: map[string]depTreeNode{
+				moduleId: {Children: []string{}},
+			},
+		})
+	}
+	return buildGraphFromModuleDepTrees(depTrees)
+}
+
+func isSupportedArtifact(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == artifactExtJar || ext == artifactExtWar || ext == artifactExtEar
+}
+
+// readArtifactGav extracts "groupId:artifactId:version" from the artifact at artifactPath, reading
+// the embedded pom.properties if one is bundled (the common case for artifacts built by Maven), or
+// falling back to MANIFEST.MF's Implementation-* attributes otherwise.
+func readArtifactGav(artifactPath string) (string, error) {
+	zipReader, err := zip.OpenReader(artifactPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = zipReader.Close()
+	}()
+
+	for _, file := range zipReader.File {
+		if strings.HasSuffix(file.Name, pomPropertiesSuffix) {
+			if gav, propErr := gavFromPomProperties(file); propErr == nil && gav != "" {
+				return gav, nil
+			}
+		}
+	}
+	for _, file := range zipReader.File {
+		if file.Name == manifestPath {
+			return gavFromManifest(file)
+		}
+	}
+	return "", errorutils.CheckErrorf("no pom.properties or MANIFEST.MF GAV coordinates found in %s", filepath.Base(artifactPath))
+}
+
+func gavFromPomProperties(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	props := map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		props[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	groupId, artifactId, version := props["groupId"], props["artifactId"], props["version"]
+	if groupId == "" || artifactId == "" || version == "" {
+		return "", errorutils.CheckErrorf("incomplete GAV coordinates in pom.properties")
+	}
+	return strings.Join([]string{groupId, artifactId, version}, ":"), nil
+}
+
+func gavFromManifest(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	attrs := map[string]string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		attrs[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	groupId, artifactId, version := attrs["Implementation-Vendor-Id"], attrs["Implementation-Title"], attrs["Implementation-Version"]
+	if groupId == "" || artifactId == "" || version == "" {
+		return "", errorutils.CheckErrorf("incomplete GAV coordinates in MANIFEST.MF")
+	}
+	return strings.Join([]string{groupId, artifactId, version}, ":"), nil
+}
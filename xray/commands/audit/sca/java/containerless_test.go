@@ -0,0 +1,135 @@
+package java
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+func TestReadArtifactGavFromPomProperties(t *testing.T) {
+	artifactPath := writeTestArtifact(t, map[string]string{
+		"META-INF/maven/org.jfrog/example/pom.properties": "groupId=org.jfrog\nartifactId=example\nversion=1.2.3\n",
+	})
+
+	gav, err := readArtifactGav(artifactPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "org.jfrog:example:1.2.3"; gav != expected {
+		t.Fatalf("expected %q, got %q", expected, gav)
+	}
+}
+
+func TestReadArtifactGavFallsBackToManifest(t *testing.T) {
+	artifactPath := writeTestArtifact(t, map[string]string{
+		manifestPath: "Manifest-Version: 1.0\n" +
+			"Implementation-Vendor-Id: org.jfrog\n" +
+			"Implementation-Title: example\n" +
+			"Implementation-Version: 1.2.3\n",
+	})
+
+	gav, err := readArtifactGav(artifactPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "org.jfrog:example:1.2.3"; gav != expected {
+		t.Fatalf("expected %q, got %q", expected, gav)
+	}
+}
+
+func TestReadArtifactGavNoCoordinates(t *testing.T) {
+	artifactPath := writeTestArtifact(t, map[string]string{"README.txt": "no GAV coordinates here"})
+
+	if _, err := readArtifactGav(artifactPath); err == nil {
+		t.Fatal("expected an error when no pom.properties or MANIFEST.MF is present")
+	}
+}
+
+func TestIsSupportedArtifact(t *testing.T) {
+	tests := map[string]bool{
+		"example.jar": true,
+		"example.war": true,
+		"example.ear": true,
+		"example.txt": false,
+		"example":     false,
+	}
+	for name, expected := range tests {
+		if actual := isSupportedArtifact(name); actual != expected {
+			t.Errorf("isSupportedArtifact(%q) = %v, expected %v", name, actual, expected)
+		}
+	}
+}
+
+func TestHasBuildToolManifest(t *testing.T) {
+	dir := t.TempDir()
+	restoreWd := chdir(t, dir)
+	defer restoreWd()
+
+	if hasBuildToolManifest(coreutils.Maven) {
+		t.Fatal("expected no Maven manifest in an empty directory")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project/>"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if !hasBuildToolManifest(coreutils.Maven) {
+		t.Fatal("expected pom.xml to be detected as a Maven manifest")
+	}
+	if hasBuildToolManifest(coreutils.Gradle) {
+		t.Fatal("a pom.xml shouldn't be detected as a Gradle manifest")
+	}
+}
+
+func TestValidateJarRejectsPlaceholder(t *testing.T) {
+	if err := validateJar([]byte{}); err == nil {
+		t.Fatal("expected an empty payload to fail validation")
+	}
+	if err := validateJar([]byte("not a jar")); err == nil {
+		t.Fatal("expected a non-zip payload to fail validation")
+	}
+}
+
+// writeTestArtifact builds a zip (standing in for a .jar) under a temp dir with the given entries
+// and returns its path.
+func writeTestArtifact(t *testing.T, entries map[string]string) string {
+	t.Helper()
+	artifactPath := filepath.Join(t.TempDir(), "example.jar")
+	file, err := os.Create(artifactPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	zipWriter := zip.NewWriter(file)
+	for name, content := range entries {
+		entryWriter, writeErr := zipWriter.Create(name)
+		if writeErr != nil {
+			t.Fatal(writeErr)
+		}
+		if _, writeErr = entryWriter.Write([]byte(content)); writeErr != nil {
+			t.Fatal(writeErr)
+		}
+	}
+	if err = zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return artifactPath
+}
+
+func chdir(t *testing.T, dir string) func() {
+	t.Helper()
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		_ = os.Chdir(originalWd)
+	}
+}
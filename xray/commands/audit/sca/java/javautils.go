@@ -1,11 +1,16 @@
 package java
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/jfrog/gofrog/datastructures"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands/audit/sbom"
 	xrayutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	scanservices "github.com/jfrog/jfrog-client-go/xray/services"
 	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
 	"os"
 	"strconv"
@@ -30,6 +35,19 @@ type DependencyTreeParams struct {
 	UseWrapper       bool
 	Server           *config.ServerDetails
 	DepsRepo         string
+	// Containerless, when set, skips wrapper/POM discovery of mvn/gradle altogether and instead
+	// drives the bundled maven-dep-tree/gradle-dep-tree JARs with JavaHome, or - if no build tool
+	// manifest is found - scans a directory of pre-built .jar/.war/.ear artifacts directly.
+	Containerless bool
+	// JavaHome is the JDK used to run the bundled dep-tree JARs in Containerless mode. When empty,
+	// the "java" executable found on the PATH is used.
+	JavaHome string
+	// Watches, ProjectKey and TargetRepoPath carry the violation context the resulting dependency
+	// tree is scanned under: when any of them is set, the Xray scan-graph request built from this
+	// tree is policy-gated, so Xray returns violations instead of raw vulnerabilities.
+	Watches        []string
+	ProjectKey     string
+	TargetRepoPath string
 }
 
 func createBuildConfiguration(buildName string) (*artifactoryUtils.BuildConfiguration, func() error) {
@@ -137,6 +155,9 @@ func hasLoop(idsAdded []string, idToAdd string) bool {
 }
 
 func BuildDependencyTree(params xrayutils.AuditParams, tech coreutils.Technology) ([]*xrayUtils.GraphNode, []string, error) {
+	if sbomPath := params.SbomPath(); sbomPath != "" {
+		return buildDependencyTreeFromSbom(sbomPath)
+	}
 	serverDetails, err := params.ServerDetails()
 	if err != nil {
 		return nil, nil, err
@@ -149,6 +170,14 @@ func BuildDependencyTree(params xrayutils.AuditParams, tech coreutils.Technology
 		UseWrapper:       params.UseWrapper(),
 		Server:           serverDetails,
 		DepsRepo:         params.DepsRepo(),
+		Containerless:    params.Containerless(),
+		JavaHome:         params.JavaHome(),
+		Watches:          params.Watches(),
+		ProjectKey:       params.ProjectKey(),
+		TargetRepoPath:   params.TargetRepoPath(),
+	}
+	if dependencyTreeParams.Containerless {
+		return buildContainerlessDependencyTree(dependencyTreeParams)
 	}
 	if tech == coreutils.Maven {
 		return buildMvnDependencyTree(dependencyTreeParams)
@@ -156,6 +185,40 @@ func BuildDependencyTree(params xrayutils.AuditParams, tech coreutils.Technology
 	return buildGradleDependencyTree(dependencyTreeParams)
 }
 
+// BuildScanGraphParams builds the Xray scan-graph request for dependencyTree, one per module,
+// carrying the violation context (Watches/ProjectKey/TargetRepoPath) collected onto treeParams by
+// BuildDependencyTree. Whenever any of them is set, IncludeVulnerabilities is turned off so Xray
+// returns policy-gated violations for the request instead of raw vulnerabilities.
+func BuildScanGraphParams(dependencyTree []*xrayUtils.GraphNode, treeParams *DependencyTreeParams) []*scanservices.XrayGraphScanParams {
+	hasViolationContext := len(treeParams.Watches) > 0 || treeParams.ProjectKey != "" || treeParams.TargetRepoPath != ""
+	scanGraphParams := make([]*scanservices.XrayGraphScanParams, len(dependencyTree))
+	for i, module := range dependencyTree {
+		scanGraphParams[i] = &scanservices.XrayGraphScanParams{
+			Graph:                  module,
+			RepoPath:               treeParams.TargetRepoPath,
+			Watches:                treeParams.Watches,
+			ProjectKey:             treeParams.ProjectKey,
+			IncludeVulnerabilities: !hasViolationContext,
+			IncludeLicenses:        true,
+		}
+	}
+	return scanGraphParams
+}
+
+// buildDependencyTreeFromSbom imports a pre-built CycloneDX/SPDX SBOM instead of invoking a build
+// tool, so --sbom=path.json lets BuildDependencyTree audit pre-built artifacts or third-party SBOMs
+// without a working Maven/Gradle environment.
+func buildDependencyTreeFromSbom(sbomPath string) ([]*xrayUtils.GraphNode, []string, error) {
+	file, err := os.Open(sbomPath)
+	if errorutils.CheckError(err) != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return sbom.ImportSBOM(file)
+}
+
 type dependencyMultimap struct {
 	multimap map[string]map[string]*buildinfo.Dependency
 }
@@ -194,6 +257,14 @@ func getGraphFromDepTree(depTreeOutput []byte) (depsGraph []*xrayUtils.GraphNode
 	if err != nil {
 		return
 	}
+	return buildGraphFromModuleDepTrees(modules)
+}
+
+// buildGraphFromModuleDepTrees converts already-parsed moduleDepTree structures into the GraphNode
+// slice consumed by the Xray scan-graph flow. It is shared by the regular maven-dep-tree/gradle-dep-tree
+// output path (getGraphFromDepTree) and the containerless path, which constructs the moduleDepTree
+// structures in memory instead of reading them from the plugin's output files.
+func buildGraphFromModuleDepTrees(modules []*moduleDepTree) (depsGraph []*xrayUtils.GraphNode, uniqueDeps []string, err error) {
 	uniqueDepsSet := datastructures.MakeSet[string]()
 	for _, moduleTree := range modules {
 		directDependency := &xrayUtils.GraphNode{
@@ -207,20 +278,32 @@ func getGraphFromDepTree(depTreeOutput []byte) (depsGraph []*xrayUtils.GraphNode
 	return
 }
 
-func populateDependencyTree(currNode *xrayUtils.GraphNode, currNodeId string, moduleTree *moduleDepTree, uniqueDepsSet *datastructures.Set[string]) {
-	if currNode.NodeHasLoop() {
-		return
+// populateDependencyTree walks moduleTree using an explicit stack rather than recursion, so
+// dependency graphs with very deep nesting (seen in large monorepo Gradle projects) don't risk
+// blowing the goroutine stack.
+func populateDependencyTree(rootNode *xrayUtils.GraphNode, rootNodeId string, moduleTree *moduleDepTree, uniqueDepsSet *datastructures.Set[string]) {
+	type stackEntry struct {
+		node   *xrayUtils.GraphNode
+		nodeId string
 	}
-	for _, childId := range moduleTree.Nodes[currNodeId].Children {
-		childGav := GavPackageTypeIdentifier + childId
-		childNode := &xrayUtils.GraphNode{
-			Id:     childGav,
-			Nodes:  []*xrayUtils.GraphNode{},
-			Parent: currNode,
+	stack := []stackEntry{{rootNode, rootNodeId}}
+	for len(stack) > 0 {
+		entry := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if entry.node.NodeHasLoop() {
+			continue
+		}
+		for _, childId := range moduleTree.Nodes[entry.nodeId].Children {
+			childGav := GavPackageTypeIdentifier + childId
+			childNode := &xrayUtils.GraphNode{
+				Id:     childGav,
+				Nodes:  []*xrayUtils.GraphNode{},
+				Parent: entry.node,
+			}
+			uniqueDepsSet.Add(childGav)
+			entry.node.Nodes = append(entry.node.Nodes, childNode)
+			stack = append(stack, stackEntry{childNode, childId})
 		}
-		uniqueDepsSet.Add(childGav)
-		populateDependencyTree(childNode, childId, moduleTree, uniqueDepsSet)
-		currNode.Nodes = append(currNode.Nodes, childNode)
 	}
 }
 
@@ -237,12 +320,94 @@ func parseDepTreeFiles(jsonFilePaths []byte) ([]*moduleDepTree, error) {
 	return modules, nil
 }
 
+// parseDepTreeFile reads the dep-tree plugin's output with a streaming json.Decoder instead of
+// loading it fully into memory with os.ReadFile+json.Unmarshal, since large monorepo Gradle
+// projects can produce "nodes" maps with tens of thousands of entries.
 func parseDepTreeFile(path string) (results *moduleDepTree, err error) {
-	depTreeJson, err := os.ReadFile(strings.TrimSpace(path))
+	file, err := os.Open(strings.TrimSpace(path))
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	results = &moduleDepTree{}
-	err = errorutils.CheckError(json.Unmarshal(depTreeJson, &results))
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+
+	results = &moduleDepTree{Nodes: make(map[string]depTreeNode)}
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	if err = errorutils.CheckError(expectDelim(decoder, json.Delim('{'))); err != nil {
+		return
+	}
+	for decoder.More() {
+		var key string
+		if err = errorutils.CheckError(decodeToken(decoder, &key)); err != nil {
+			return
+		}
+		switch key {
+		case "root":
+			if err = errorutils.CheckError(decoder.Decode(&results.Root)); err != nil {
+				return
+			}
+		case "nodes":
+			if err = parseDepTreeNodes(decoder, results.Nodes); err != nil {
+				return
+			}
+		default:
+			// Skip values of fields the audit flow doesn't use, so future additions to the plugin's
+			// output don't break parsing.
+			var discarded any
+			if err = errorutils.CheckError(decoder.Decode(&discarded)); err != nil {
+				return
+			}
+		}
+	}
+	err = errorutils.CheckError(expectDelim(decoder, json.Delim('}')))
 	return
 }
+
+// parseDepTreeNodes streams through the "nodes" object token-by-token, decoding one depTreeNode at
+// a time into nodes, instead of materializing the whole map in a single json.Unmarshal call.
+func parseDepTreeNodes(decoder *json.Decoder, nodes map[string]depTreeNode) error {
+	if err := expectDelim(decoder, json.Delim('{')); err != nil {
+		return err
+	}
+	for decoder.More() {
+		var nodeId string
+		if err := decodeToken(decoder, &nodeId); err != nil {
+			return err
+		}
+		var node depTreeNode
+		if err := decoder.Decode(&node); err != nil {
+			return err
+		}
+		nodes[nodeId] = node
+	}
+	return expectDelim(decoder, json.Delim('}'))
+}
+
+// expectDelim reads the next token from decoder and errors if it isn't the given JSON delimiter.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	gotDelim, ok := token.(json.Delim)
+	if !ok || gotDelim != delim {
+		return fmt.Errorf("expected '%s', got %v", delim, token)
+	}
+	return nil
+}
+
+// decodeToken reads the next token from decoder, which must be a string (e.g. an object key), and
+// assigns it to out; any other token is an error.
+func decodeToken(decoder *json.Decoder, out *string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	value, ok := token.(string)
+	if !ok {
+		return fmt.Errorf("expected a string token, got %v", token)
+	}
+	*out = value
+	return nil
+}
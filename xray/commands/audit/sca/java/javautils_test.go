@@ -0,0 +1,81 @@
+package java
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	xrayUtils "github.com/jfrog/jfrog-client-go/xray/services/utils"
+)
+
+func TestBuildScanGraphParamsNoViolationContext(t *testing.T) {
+	module := &xrayUtils.GraphNode{Id: "gav://org.jfrog:example:1.0.0"}
+
+	scanGraphParams := BuildScanGraphParams([]*xrayUtils.GraphNode{module}, &DependencyTreeParams{})
+
+	if len(scanGraphParams) != 1 {
+		t.Fatalf("expected 1 scan-graph param, got %d", len(scanGraphParams))
+	}
+	if !scanGraphParams[0].IncludeVulnerabilities {
+		t.Fatal("expected IncludeVulnerabilities to be true when no violation context is set")
+	}
+}
+
+func TestBuildScanGraphParamsWithViolationContext(t *testing.T) {
+	module := &xrayUtils.GraphNode{Id: "gav://org.jfrog:example:1.0.0"}
+	treeParams := &DependencyTreeParams{Watches: []string{"watch-1"}, ProjectKey: "my-project", TargetRepoPath: "repo/path"}
+
+	scanGraphParams := BuildScanGraphParams([]*xrayUtils.GraphNode{module}, treeParams)
+
+	result := scanGraphParams[0]
+	if result.IncludeVulnerabilities {
+		t.Fatal("expected IncludeVulnerabilities to be false when violation context is set")
+	}
+	if result.Graph != module {
+		t.Fatal("expected the Graph field to be the module's dependency tree")
+	}
+	if result.ProjectKey != treeParams.ProjectKey || result.RepoPath != treeParams.TargetRepoPath {
+		t.Fatal("expected ProjectKey/RepoPath to be carried over from DependencyTreeParams")
+	}
+	if len(result.Watches) != 1 || result.Watches[0] != "watch-1" {
+		t.Fatal("expected Watches to be carried over from DependencyTreeParams")
+	}
+}
+
+// BenchmarkParseDepTreeFile locks in the streaming parser's improvement over a full
+// os.ReadFile+json.Unmarshal on a synthetic 50k-node tree, the scale seen in large monorepo
+// Gradle projects.
+func BenchmarkParseDepTreeFile(b *testing.B) {
+	path := writeSyntheticDepTreeFile(b, 50_000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseDepTreeFile(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func writeSyntheticDepTreeFile(b *testing.B, nodeCount int) string {
+	nodes := make(map[string]depTreeNode, nodeCount)
+	for i := 0; i < nodeCount; i++ {
+		var children []string
+		if i < nodeCount-1 {
+			children = []string{fmt.Sprintf("dep:%d", i+1)}
+		}
+		nodes[fmt.Sprintf("dep:%d", i)] = depTreeNode{Children: children}
+	}
+	tree := moduleDepTree{Root: "dep:0", Nodes: nodes}
+
+	treeJson, err := json.Marshal(tree)
+	if err != nil {
+		b.Fatal(err)
+	}
+	depTreeFilePath := filepath.Join(b.TempDir(), "dep-tree.json")
+	if err = os.WriteFile(depTreeFilePath, treeJson, 0666); err != nil {
+		b.Fatal(err)
+	}
+	return depTreeFilePath
+}